@@ -0,0 +1,150 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindBranchPoints(t *testing.T) {
+	src := `package p
+
+func F(n int) int {
+	if n > 0 {
+		return 1
+	} else {
+		return -1
+	}
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	require.NoError(t, err)
+
+	points := findBranchPoints(fset, file)
+	require.Len(t, points, 1)
+	require.Equal(t, 4, points[0].line)
+	require.Len(t, points[0].arms, 2)
+}
+
+func TestFindBranchPointsAddsFallthroughArmWhenNoElse(t *testing.T) {
+	src := `package p
+
+func F(n int) int {
+	if n > 0 {
+		return 1
+	}
+	return -1
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	require.NoError(t, err)
+
+	points := findBranchPoints(fset, file)
+	require.Len(t, points, 1)
+	require.Len(t, points[0].arms, 2)
+	require.Equal(t, 7, fset.Position(points[0].arms[1]).Line)
+}
+
+func TestFindBranchPointsAddsFallthroughArmInsideCaseClause(t *testing.T) {
+	src := `package p
+
+func F(n int) int {
+	switch n {
+	case 1:
+		if n > 0 {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	require.NoError(t, err)
+
+	points := findBranchPoints(fset, file)
+
+	var ifPoint *branchPoint
+	for i, p := range points {
+		if p.line == 6 {
+			ifPoint = &points[i]
+		}
+	}
+	require.NotNil(t, ifPoint)
+	require.Len(t, ifPoint.arms, 2)
+	// The fallthrough arm should resolve to the "return -1" statement
+	// (line 9), not just past the if-statement's own closing brace
+	// (line 8), since that if is a non-last statement in its
+	// *ast.CaseClause body rather than a *ast.BlockStmt.
+	require.Equal(t, 9, fset.Position(ifPoint.arms[1]).Line)
+}
+
+func TestAnnotateBranchesGuardClauseWithoutElse(t *testing.T) {
+	// Mirrors what `go tool cover` reports for this exact shape: the
+	// if-body block runs through the closing brace line (6), and the
+	// statement after the if gets its own block (7).
+	src := `package p
+
+func F(n int) int {
+	if n > 0 {
+		return 1
+	}
+	return -1
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	require.NoError(t, err)
+
+	blocks := []ProfileBlock{
+		{StartLine: 3, EndLine: 4, NumStmt: 1, Count: 1},
+		{StartLine: 4, EndLine: 6, NumStmt: 1, Count: 1},
+		{StartLine: 7, EndLine: 7, NumStmt: 1, Count: 0},
+	}
+	cls := &Class{Lines: Lines{{Number: 4, Hits: 1}}}
+
+	annotateBranches(cls, fset, file, blocks)
+
+	l := cls.Lines[0]
+	require.True(t, l.Branch)
+	require.Equal(t, "50% (1/2)", l.ConditionCoverage)
+	require.Len(t, l.Conditions, 2)
+	require.Equal(t, "covered", l.Conditions[0].Coverage)
+	require.Equal(t, "not covered", l.Conditions[1].Coverage)
+}
+
+func TestAnnotateBranchesMarksPartialCoverage(t *testing.T) {
+	src := `package p
+
+func F(n int) int {
+	if n > 0 {
+		return 1
+	} else {
+		return -1
+	}
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	require.NoError(t, err)
+
+	blocks := []ProfileBlock{
+		{StartLine: 4, EndLine: 5, NumStmt: 1, Count: 1},
+		{StartLine: 6, EndLine: 7, NumStmt: 1, Count: 0},
+	}
+	cls := &Class{Lines: Lines{{Number: 4, Hits: 1}}}
+
+	annotateBranches(cls, fset, file, blocks)
+
+	l := cls.Lines[0]
+	require.True(t, l.Branch)
+	require.Equal(t, "50% (1/2)", l.ConditionCoverage)
+	require.Len(t, l.Conditions, 2)
+	require.Equal(t, "covered", l.Conditions[0].Coverage)
+	require.Equal(t, "not covered", l.Conditions[1].Coverage)
+}
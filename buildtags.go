@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"go/build/constraint"
+	"strings"
+)
+
+// buildConstraintsSatisfied reports whether a source file's build
+// constraints (`//go:build` and legacy `// +build` comments) are
+// satisfied by ignore's tag set, so that files that were never
+// compiled for the configuration under test can be dropped from the
+// report instead of showing up as spurious 0-hit lines.
+func buildConstraintsSatisfied(data []byte, ignore *Ignore) bool {
+	if len(ignore.BuildTags) == 0 && ignore.GOOS == "" && ignore.GOARCH == "" {
+		return true
+	}
+
+	tags := make(map[string]bool, len(ignore.BuildTags)+2)
+	for _, t := range ignore.BuildTags {
+		tags[t] = true
+	}
+	if ignore.GOOS != "" {
+		tags[ignore.GOOS] = true
+	}
+	if ignore.GOARCH != "" {
+		tags[ignore.GOARCH] = true
+	}
+
+	satisfied := true
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+		if !strings.HasPrefix(line, "//") {
+			continue
+		}
+		if !constraint.IsGoBuild(line) && !constraint.IsPlusBuild(line) {
+			continue
+		}
+		expr, err := constraint.Parse(line)
+		if err != nil {
+			continue
+		}
+		if !expr.Eval(func(tag string) bool { return tags[tag] }) {
+			satisfied = false
+		}
+	}
+	return satisfied
+}
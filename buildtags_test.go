@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildConstraintsSatisfiedGoBuild(t *testing.T) {
+	src := []byte("//go:build linux\n\npackage p\n")
+
+	require.True(t, buildConstraintsSatisfied(src, &Ignore{GOOS: "linux"}))
+	require.False(t, buildConstraintsSatisfied(src, &Ignore{GOOS: "windows"}))
+	require.True(t, buildConstraintsSatisfied(src, &Ignore{}))
+}
+
+func TestBuildConstraintsSatisfiedPlusBuild(t *testing.T) {
+	src := []byte("// +build linux\n\npackage p\n")
+
+	require.True(t, buildConstraintsSatisfied(src, &Ignore{GOOS: "linux"}))
+	require.False(t, buildConstraintsSatisfied(src, &Ignore{GOOS: "darwin"}))
+}
+
+func TestBuildConstraintsSatisfiedTag(t *testing.T) {
+	src := []byte("//go:build integration\n\npackage p\n")
+
+	require.True(t, buildConstraintsSatisfied(src, &Ignore{BuildTags: []string{"integration"}}))
+	require.False(t, buildConstraintsSatisfied(src, &Ignore{BuildTags: []string{"unit"}}))
+}
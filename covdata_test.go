@@ -0,0 +1,20 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCoverDir(t *testing.T) {
+	dir := t.TempDir()
+	require.True(t, isCoverDir(dir))
+
+	file := filepath.Join(dir, "profile.out")
+	require.NoError(t, os.WriteFile(file, []byte("mode: set\n"), 0o644))
+	require.False(t, isCoverDir(file))
+
+	require.False(t, isCoverDir(filepath.Join(dir, "does-not-exist")))
+}
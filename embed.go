@@ -0,0 +1,74 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// findEmbedLines returns the set of source lines occupied by var specs
+// annotated with a `//go:embed` directive, so they can be excluded from
+// a Class's reported lines. The compiler attributes a coverage block to
+// these declarations even though they contain no executable code, which
+// otherwise shows up as a permanently-uncovered line.
+func findEmbedLines(fset *token.FileSet, file *ast.File) map[int]bool {
+	lines := make(map[int]bool)
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			// For the common, un-parenthesized form
+			//   //go:embed testdata
+			//   var f embed.FS
+			// go/parser attaches the comment to the GenDecl, not the
+			// ValueSpec; the ValueSpec only carries its own Doc when the
+			// declaration is parenthesized.
+			doc := vs.Doc
+			if doc == nil && gd.Lparen == token.NoPos && len(gd.Specs) == 1 {
+				doc = gd.Doc
+			}
+			if !hasEmbedDirective(doc) {
+				continue
+			}
+			start := fset.Position(vs.Pos()).Line
+			end := fset.Position(vs.End()).Line
+			for ln := start; ln <= end; ln++ {
+				lines[ln] = true
+			}
+		}
+	}
+	return lines
+}
+
+func hasEmbedDirective(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if strings.HasPrefix(c.Text, "//go:embed") {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutLines returns lines with every entry whose Number is in drop
+// removed, preserving order.
+func withoutLines(lines Lines, drop map[int]bool) Lines {
+	if len(drop) == 0 {
+		return lines
+	}
+	kept := lines[:0:0]
+	for _, l := range lines {
+		if !drop[l.Number] {
+			kept = append(kept, l)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindEmbedLinesUnparenthesized(t *testing.T) {
+	src := `package p
+
+import "embed"
+
+//go:embed testdata
+var f embed.FS
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	lines := findEmbedLines(fset, file)
+	require.True(t, lines[6])
+}
+
+func TestFindEmbedLinesParenthesized(t *testing.T) {
+	src := `package p
+
+import "embed"
+
+var (
+	//go:embed testdata
+	f embed.FS
+)
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	lines := findEmbedLines(fset, file)
+	require.True(t, lines[7])
+}
+
+func TestFindEmbedLinesIgnoresPlainVar(t *testing.T) {
+	src := `package p
+
+var f int
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	require.Empty(t, findEmbedLines(fset, file))
+}
@@ -0,0 +1,700 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const coberturaDTDDecl = `<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">`
+
+var lineRe = regexp.MustCompile(`^(.+):([0-9]+)\.([0-9]+),([0-9]+)\.([0-9]+) ([0-9]+) ([0-9]+)$`)
+
+// ProfileBlock represents a single block of code as reported in a Go
+// coverage profile: a span of source positions together with the number
+// of statements it contains and how many times it was executed.
+type ProfileBlock struct {
+	StartLine, StartCol int
+	EndLine, EndCol     int
+	NumStmt, Count      int
+}
+
+// Profile is the parsed form of one file's worth of lines in a Go
+// coverage profile.
+type Profile struct {
+	FileName string
+	Mode     string
+	Blocks   []ProfileBlock
+}
+
+type profilesByFileName []*Profile
+
+func (p profilesByFileName) Len() int           { return len(p) }
+func (p profilesByFileName) Less(i, j int) bool { return p[i].FileName < p[j].FileName }
+func (p profilesByFileName) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+type blocksByStart []ProfileBlock
+
+func (b blocksByStart) Len() int { return len(b) }
+func (b blocksByStart) Less(i, j int) bool {
+	bi, bj := b[i], b[j]
+	return bi.StartLine < bj.StartLine || (bi.StartLine == bj.StartLine && bi.StartCol < bj.StartCol)
+}
+func (b blocksByStart) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+
+// parseProfiles parses a Go coverage profile in "mode: ..." text form,
+// as produced by `go test -coverprofile`.
+func parseProfiles(in io.Reader) ([]*Profile, error) {
+	files := make(map[string]*Profile)
+	var order []string
+
+	s := bufio.NewScanner(in)
+	mode := ""
+	for s.Scan() {
+		line := s.Text()
+		if mode == "" {
+			const p = "mode: "
+			if !strings.HasPrefix(line, p) || line == p {
+				return nil, fmt.Errorf("bad mode line: %v", line)
+			}
+			mode = line[len(p):]
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		m := lineRe.FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf("line %q doesn't match expected format: %v", line, lineRe)
+		}
+		fn := m[1]
+		p := files[fn]
+		if p == nil {
+			p = &Profile{FileName: fn, Mode: mode}
+			files[fn] = p
+			order = append(order, fn)
+		}
+		startLine, _ := strconv.Atoi(m[2])
+		startCol, _ := strconv.Atoi(m[3])
+		endLine, _ := strconv.Atoi(m[4])
+		endCol, _ := strconv.Atoi(m[5])
+		numStmt, _ := strconv.Atoi(m[6])
+		count, _ := strconv.Atoi(m[7])
+		p.Blocks = append(p.Blocks, ProfileBlock{
+			StartLine: startLine, StartCol: startCol,
+			EndLine: endLine, EndCol: endCol,
+			NumStmt: numStmt, Count: count,
+		})
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	profiles := make([]*Profile, 0, len(order))
+	for _, fn := range order {
+		profiles = append(profiles, files[fn])
+	}
+	sort.Sort(profilesByFileName(profiles))
+	for _, p := range profiles {
+		sort.Sort(blocksByStart(p.Blocks))
+	}
+	return profiles, nil
+}
+
+// Ignore controls which source files are excluded from the generated
+// report.
+type Ignore struct {
+	GeneratedFiles bool
+	Files          *regexp.Regexp
+	BuildTags      []string
+	GOOS           string
+	GOARCH         string
+	SourceOverlay  *Overlay
+	SourcePrefixes []prefixRemap
+}
+
+// Coverage is the root element of a Cobertura coverage report.
+type Coverage struct {
+	XMLName         xml.Name   `xml:"coverage"`
+	LineRate        float64    `xml:"line-rate,attr"`
+	BranchRate      float64    `xml:"branch-rate,attr"`
+	LinesCovered    int64      `xml:"lines-covered,attr"`
+	LinesValid      int64      `xml:"lines-valid,attr"`
+	BranchesCovered int64      `xml:"branches-covered,attr"`
+	BranchesValid   int64      `xml:"branches-valid,attr"`
+	Complexity      float64    `xml:"complexity,attr"`
+	Version         string     `xml:"version,attr"`
+	Timestamp       int64      `xml:"timestamp,attr"`
+	Sources         []*Source  `xml:"sources>source"`
+	Packages        []*Package `xml:"packages>package"`
+}
+
+// Source is a single root directory that line/filename references in
+// this report are relative to.
+type Source struct {
+	Path string `xml:",chardata"`
+}
+
+// Package is a Cobertura package, corresponding to a single Go package.
+type Package struct {
+	Name       string   `xml:"name,attr"`
+	LineRate   float64  `xml:"line-rate,attr"`
+	BranchRate float64  `xml:"branch-rate,attr"`
+	Complexity float64  `xml:"complexity,attr"`
+	Classes    []*Class `xml:"classes>class"`
+}
+
+// Class is a Cobertura class, corresponding to a single Go source file.
+type Class struct {
+	Name       string    `xml:"name,attr"`
+	Filename   string    `xml:"filename,attr"`
+	LineRate   float64   `xml:"line-rate,attr"`
+	BranchRate float64   `xml:"branch-rate,attr"`
+	Complexity float64   `xml:"complexity,attr"`
+	Methods    []*Method `xml:"methods>method"`
+	Lines      Lines     `xml:"lines>line"`
+}
+
+// Method is a Cobertura method, corresponding to a single Go function.
+type Method struct {
+	Name       string  `xml:"name,attr"`
+	Signature  string  `xml:"signature,attr"`
+	LineRate   float64 `xml:"line-rate,attr"`
+	BranchRate float64 `xml:"branch-rate,attr"`
+	Lines      Lines   `xml:"lines>line"`
+}
+
+// Lines is a collection of Line elements, sortable by line number.
+type Lines []*Line
+
+func (l Lines) Len() int           { return len(l) }
+func (l Lines) Less(i, j int) bool { return l[i].Number < l[j].Number }
+func (l Lines) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
+
+// Condition is a single branch direction within a Line's condition
+// coverage, e.g. the "true" or "false" arm of an if statement.
+type Condition struct {
+	Number   int    `xml:"number,attr"`
+	Type     string `xml:"type,attr"`
+	Coverage string `xml:"coverage,attr"`
+}
+
+// Line is a single source line, optionally annotated with branch
+// coverage when it is a branch point.
+type Line struct {
+	Number            int          `xml:"number,attr"`
+	Hits              int          `xml:"hits,attr"`
+	Branch            bool         `xml:"branch,attr,omitempty"`
+	ConditionCoverage string       `xml:"condition-coverage,attr,omitempty"`
+	Conditions        []*Condition `xml:"conditions>condition,omitempty"`
+}
+
+// branchPoint is a single point in the source where execution can
+// diverge, together with the positions of each arm that can be taken.
+type branchPoint struct {
+	line int
+	arms []token.Pos
+}
+
+// findBranchPoints walks a file's AST and returns the branch points
+// introduced by if/switch/select statements and short-circuit
+// operators.
+func findBranchPoints(fset *token.FileSet, file *ast.File) []branchPoint {
+	var points []branchPoint
+
+	add := func(pos token.Pos, arms ...token.Pos) {
+		if len(arms) == 0 {
+			return
+		}
+		points = append(points, branchPoint{line: fset.Position(pos).Line, arms: arms})
+	}
+
+	// fallthroughPos maps an else-less *ast.IfStmt to the position of
+	// the statement immediately following it in the same block, so
+	// the implicit "condition was false" arm can be checked against
+	// the block that statement belongs to rather than against the
+	// if-body's own block (which, since coverage blocks run through
+	// to the line of the closing brace, would otherwise make that arm
+	// look covered whenever the body was). Populated while visiting
+	// each *ast.BlockStmt, which happens before its child statements
+	// are visited, since ast.Inspect walks in pre-order.
+	fallthroughPos := make(map[*ast.IfStmt]token.Pos)
+	recordFallthroughs := func(list []ast.Stmt) {
+		for i, s := range list {
+			ifs, ok := s.(*ast.IfStmt)
+			if !ok || ifs.Else != nil || i+1 >= len(list) {
+				continue
+			}
+			fallthroughPos[ifs] = list[i+1].Pos()
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.BlockStmt:
+			recordFallthroughs(stmt.List)
+		case *ast.CaseClause:
+			recordFallthroughs(stmt.Body)
+		case *ast.CommClause:
+			recordFallthroughs(stmt.Body)
+		case *ast.IfStmt:
+			arms := []token.Pos{stmt.Body.Pos()}
+			if stmt.Else != nil {
+				arms = append(arms, stmt.Else.Pos())
+			} else if next, ok := fallthroughPos[stmt]; ok {
+				// The if is not the last statement in its block: the
+				// untaken arm is "control reached the next statement
+				// without entering the body".
+				arms = append(arms, next)
+			} else {
+				// No known next statement (e.g. the if is the last
+				// statement in its block): fall back to the position
+				// right after the if-statement itself.
+				arms = append(arms, stmt.End())
+			}
+			add(stmt.Pos(), arms...)
+		case *ast.SwitchStmt:
+			var arms []token.Pos
+			for _, c := range stmt.Body.List {
+				if clause, ok := c.(*ast.CaseClause); ok {
+					arms = append(arms, clause.Pos())
+				}
+			}
+			add(stmt.Pos(), arms...)
+		case *ast.TypeSwitchStmt:
+			var arms []token.Pos
+			for _, c := range stmt.Body.List {
+				if clause, ok := c.(*ast.CaseClause); ok {
+					arms = append(arms, clause.Pos())
+				}
+			}
+			add(stmt.Pos(), arms...)
+		case *ast.SelectStmt:
+			var arms []token.Pos
+			for _, c := range stmt.Body.List {
+				if clause, ok := c.(*ast.CommClause); ok {
+					arms = append(arms, clause.Pos())
+				}
+			}
+			add(stmt.Pos(), arms...)
+		case *ast.BinaryExpr:
+			if stmt.Op == token.LAND || stmt.Op == token.LOR {
+				add(stmt.Pos(), stmt.X.Pos(), stmt.Y.Pos())
+			}
+		}
+		return true
+	})
+
+	return points
+}
+
+// blockContaining returns the profile block whose span contains the
+// given line, if any.
+func blockContaining(blocks []ProfileBlock, line int) (ProfileBlock, bool) {
+	for _, b := range blocks {
+		if line >= b.StartLine && line <= b.EndLine {
+			return b, true
+		}
+	}
+	return ProfileBlock{}, false
+}
+
+// annotateBranches fills in the branch/condition-coverage attributes on
+// cls.Lines for every branch point found in file, using blocks to
+// determine whether each arm was taken.
+func annotateBranches(cls *Class, fset *token.FileSet, file *ast.File, blocks []ProfileBlock) {
+	byLine := make(map[int]*Line)
+	for _, l := range cls.Lines {
+		byLine[l.Number] = l
+	}
+
+	for _, bp := range findBranchPoints(fset, file) {
+		l, ok := byLine[bp.line]
+		if !ok {
+			continue
+		}
+		covered := 0
+		for i, armPos := range bp.arms {
+			armLine := fset.Position(armPos).Line
+			typ := "jump"
+			cov := "not covered"
+			if b, ok := blockContaining(blocks, armLine); ok && b.Count > 0 {
+				cov = "covered"
+				covered++
+			}
+			l.Conditions = append(l.Conditions, &Condition{Number: i, Type: typ, Coverage: cov})
+		}
+		l.Branch = true
+		l.ConditionCoverage = fmt.Sprintf("%d%% (%d/%d)", percent(covered, len(bp.arms)), covered, len(bp.arms))
+	}
+}
+
+func percent(covered, total int) int {
+	if total == 0 {
+		return 100
+	}
+	return covered * 100 / total
+}
+
+// findFile resolves a file name as reported in a coverage profile (an
+// import-path-relative name) to the actual path on disk, using the
+// package's GoFiles list.
+func findFile(pkg *packages.Package, fileName string) string {
+	base := filepath.Base(fileName)
+	for _, gf := range pkg.GoFiles {
+		if filepath.Base(gf) == base {
+			return gf
+		}
+	}
+	return fileName
+}
+
+// parseProfile converts a single profile for one source file into a
+// Class and appends it to the appropriate Package within cov.
+func (cov *Coverage) parseProfile(profile *Profile, pkg *packages.Package, ignore *Ignore) error {
+	if pkg == nil || pkg.Module == nil {
+		return fmt.Errorf("package required when using go modules")
+	}
+
+	diskPath := ignore.SourceOverlay.resolve(findFile(pkg, profile.FileName), ignore.SourcePrefixes)
+	data, err := ioutil.ReadFile(diskPath)
+	if err != nil {
+		return err
+	}
+
+	cov.addSource(ignore.SourceOverlay.resolve(pkg.Module.Dir, ignore.SourcePrefixes))
+
+	if ignore.Files != nil && ignore.Files.MatchString(profile.FileName) {
+		return nil
+	}
+	if ignore.GeneratedFiles && isGeneratedFile(data) {
+		return nil
+	}
+	if !buildConstraintsSatisfied(data, ignore) {
+		return nil
+	}
+
+	fileName := strings.TrimPrefix(profile.FileName, pkg.Module.Path+"/")
+
+	fset := token.NewFileSet()
+	astFile, _ := parser.ParseFile(fset, diskPath, data, parser.ParseComments)
+
+	cls := &Class{Filename: fileName}
+	if astFile != nil {
+		cls.Name = classNameFor(astFile)
+		cov.addMethods(cls, fset, astFile, profile.Blocks)
+		if embedLines := findEmbedLines(fset, astFile); len(embedLines) > 0 {
+			cls.Lines = withoutLines(cls.Lines, embedLines)
+			for _, m := range cls.Methods {
+				m.Lines = withoutLines(m.Lines, embedLines)
+			}
+		}
+		if *branchFlag {
+			annotateBranches(cls, fset, astFile, profile.Blocks)
+		}
+	} else {
+		cls.Name = "-"
+	}
+
+	sort.Sort(cls.Lines)
+	pkgReport := cov.packageFor(pkg.PkgPath)
+	pkgReport.Classes = append(pkgReport.Classes, cls)
+	return nil
+}
+
+// classNameFor picks a Cobertura "class" name for a Go source file: the
+// name of the first declared type, or "-" if the file declares none.
+func classNameFor(file *ast.File) string {
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok {
+				return ts.Name.Name
+			}
+		}
+	}
+	return "-"
+}
+
+// addMethods walks file's top-level function declarations, recording a
+// Method (and the Class-level Lines) for each one that has at least one
+// covered statement block.
+func (cov *Coverage) addMethods(cls *Class, fset *token.FileSet, file *ast.File, blocks []ProfileBlock) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		start := fset.Position(fn.Pos()).Line
+		end := fset.Position(fn.End()).Line
+
+		method := &Method{Name: fn.Name.Name}
+		byLine := make(map[int]*Line)
+		for _, b := range blocks {
+			if b.StartLine < start || b.EndLine > end {
+				continue
+			}
+			for ln := b.StartLine; ln <= b.EndLine; ln++ {
+				l, ok := byLine[ln]
+				if !ok {
+					l = &Line{Number: ln}
+					byLine[ln] = l
+					method.Lines = append(method.Lines, l)
+				}
+				if b.Count > l.Hits {
+					l.Hits = b.Count
+				}
+			}
+		}
+		if len(method.Lines) > 0 {
+			sort.Sort(method.Lines)
+			cls.Methods = append(cls.Methods, method)
+			cls.Lines = append(cls.Lines, method.Lines...)
+		}
+	}
+}
+
+// packageFor returns the Package in cov named name, creating it if
+// necessary.
+func (cov *Coverage) packageFor(name string) *Package {
+	for _, p := range cov.Packages {
+		if p.Name == name {
+			return p
+		}
+	}
+	p := &Package{Name: name}
+	cov.Packages = append(cov.Packages, p)
+	return p
+}
+
+// addSource records path as a report source root, if it isn't already
+// present and isn't empty.
+func (cov *Coverage) addSource(path string) {
+	if path == "" {
+		return
+	}
+	for _, s := range cov.Sources {
+		if s.Path == path {
+			return
+		}
+	}
+	cov.Sources = append(cov.Sources, &Source{Path: path})
+}
+
+func isGeneratedFile(data []byte) bool {
+	return bytes.Contains(data, []byte("Code generated")) && bytes.Contains(data, []byte("DO NOT EDIT"))
+}
+
+// loadPackages resolves the set of directories referenced by profiles
+// into their owning Go packages.
+func loadPackages(profiles []*Profile) (map[string]*packages.Package, error) {
+	dirs := make(map[string]bool)
+	for _, p := range profiles {
+		dirs[filepath.Dir(p.FileName)] = true
+	}
+	patterns := make([]string, 0, len(dirs))
+	for d := range dirs {
+		patterns = append(patterns, d)
+	}
+
+	cfg := &packages.Config{Mode: packages.NeedName | packages.NeedFiles | packages.NeedModule}
+	loaded, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	byPkgPath := make(map[string]*packages.Package, len(loaded))
+	for _, pkg := range loaded {
+		byPkgPath[pkg.PkgPath] = pkg
+	}
+	return byPkgPath, nil
+}
+
+func (cov *Coverage) parseProfiles(profiles []*Profile, ignore *Ignore) error {
+	byPkgPath, err := loadPackages(profiles)
+	if err != nil {
+		return err
+	}
+	for _, p := range profiles {
+		pkg := byPkgPath[filepath.Dir(p.FileName)]
+		if err := cov.parseProfile(p, pkg, ignore); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cov *Coverage) computeRates() {
+	var linesCovered, linesValid, branchesCovered, branchesValid int64
+	for _, pkg := range cov.Packages {
+		var pkgLinesCovered, pkgLinesValid, pkgBranchesCovered, pkgBranchesValid int64
+		for _, cls := range pkg.Classes {
+			for _, m := range cls.Methods {
+				mLinesCovered, mLinesValid, mBranchesCovered, mBranchesValid := lineRates(m.Lines)
+				m.LineRate = rate(mLinesCovered, mLinesValid)
+				m.BranchRate = rate(mBranchesCovered, mBranchesValid)
+			}
+
+			clsLinesCovered, clsLinesValid, clsBranchesCovered, clsBranchesValid := lineRates(cls.Lines)
+			cls.LineRate = rate(clsLinesCovered, clsLinesValid)
+			cls.BranchRate = rate(clsBranchesCovered, clsBranchesValid)
+			pkgLinesCovered += clsLinesCovered
+			pkgLinesValid += clsLinesValid
+			pkgBranchesCovered += clsBranchesCovered
+			pkgBranchesValid += clsBranchesValid
+		}
+		pkg.LineRate = rate(pkgLinesCovered, pkgLinesValid)
+		pkg.BranchRate = rate(pkgBranchesCovered, pkgBranchesValid)
+		linesCovered += pkgLinesCovered
+		linesValid += pkgLinesValid
+		branchesCovered += pkgBranchesCovered
+		branchesValid += pkgBranchesValid
+	}
+	cov.LinesCovered = linesCovered
+	cov.LinesValid = linesValid
+	cov.BranchesCovered = branchesCovered
+	cov.BranchesValid = branchesValid
+	cov.LineRate = rate(linesCovered, linesValid)
+	cov.BranchRate = rate(branchesCovered, branchesValid)
+}
+
+// lineRates tallies covered/valid lines and branch conditions across
+// lines.
+func lineRates(lines Lines) (linesCovered, linesValid, branchesCovered, branchesValid int64) {
+	for _, l := range lines {
+		linesValid++
+		if l.Hits > 0 {
+			linesCovered++
+		}
+		if l.Branch {
+			for _, c := range l.Conditions {
+				branchesValid++
+				if c.Coverage == "covered" {
+					branchesCovered++
+				}
+			}
+		}
+	}
+	return
+}
+
+func rate(covered, valid int64) float64 {
+	if valid == 0 {
+		return 0
+	}
+	return float64(covered) / float64(valid)
+}
+
+var branchFlag = flag.Bool("branch", false, "enable branch coverage reporting (walks the AST to find if/switch/select branch points; adds parsing overhead)")
+var outFlag = flag.String("outfile", "", "filename to output coverage; default is stdout")
+var ignoreGeneratedFlag = flag.Bool("ignoreGenerated", false, "ignore generated files when reporting coverage")
+var ignoreFilesFlag = flag.String("ignoreFiles", "", "regexp to match files to ignore")
+var buildTagsFlag = flag.String("buildTags", "", "comma-separated list of build tags that were set when the profile was produced; files excluded by them are ignored")
+var goosFlag = flag.String("goos", "", "GOOS the profile was produced for; files excluded by it are ignored")
+var goarchFlag = flag.String("goarch", "", "GOARCH the profile was produced for; files excluded by it are ignored")
+var sourceOverlayFlag = flag.String("source-overlay", "", "JSON overlay file (same format as `go build -overlay`) mapping original source paths to their actual location")
+var sourcePrefixFlag = flag.String("source-prefix", "", "comma-separated list of old=new source path prefix remaps, applied after -source-overlay")
+
+func convert(in io.Reader, out io.Writer, ignore *Ignore) error {
+	profiles, err := parseProfiles(in)
+	if err != nil {
+		return err
+	}
+
+	v := Coverage{}
+	if len(profiles) > 0 {
+		if err := v.parseProfiles(profiles, ignore); err != nil {
+			return err
+		}
+		v.computeRates()
+	}
+
+	if _, err := fmt.Fprint(out, xml.Header); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(out, coberturaDTDDecl); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(out)
+	enc.Indent("", "    ")
+	return enc.Encode(v)
+}
+
+// openInput resolves a single command-line argument into the text
+// profile bytes it represents: a GOCOVERDIR is run through `go tool
+// covdata textfmt`, anything else is read as a text profile file.
+func openInput(path string) ([]byte, error) {
+	if isCoverDir(path) {
+		return readCoverDir(path)
+	}
+	return ioutil.ReadFile(path)
+}
+
+func main() {
+	flag.Parse()
+
+	sourceOverlay, err := loadOverlay(*sourceOverlayFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	ignore := &Ignore{
+		GeneratedFiles: *ignoreGeneratedFlag,
+		GOOS:           *goosFlag,
+		GOARCH:         *goarchFlag,
+		SourceOverlay:  sourceOverlay,
+		SourcePrefixes: parsePrefixRemaps(*sourcePrefixFlag),
+	}
+	if *ignoreFilesFlag != "" {
+		ignore.Files = regexp.MustCompile(*ignoreFilesFlag)
+	}
+	if *buildTagsFlag != "" {
+		ignore.BuildTags = strings.Split(*buildTagsFlag, ",")
+	}
+
+	out := os.Stdout
+	if *outFlag != "" {
+		f, err := os.Create(*outFlag)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	var in io.Reader = os.Stdin
+	if args := flag.Args(); len(args) > 0 {
+		data, err := mergeInputs(args)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		in = bytes.NewReader(data)
+	}
+
+	if err := convert(in, out, ignore); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
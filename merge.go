@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// mergeInputs reads and merges every path in paths (text profiles or
+// GOCOVERDIR directories), returning the result as a single text
+// profile ready to be fed to convert. This lets CI aggregate coverage
+// from many `go test` shards, integration tests, and instrumented-
+// binary runs into one report.
+func mergeInputs(paths []string) ([]byte, error) {
+	if len(paths) == 1 {
+		return openInput(paths[0])
+	}
+
+	var sets [][]*Profile
+	for _, path := range paths {
+		data, err := openInput(path)
+		if err != nil {
+			return nil, err
+		}
+		profiles, err := parseProfiles(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		sets = append(sets, profiles)
+	}
+
+	merged, err := mergeProfiles(sets...)
+	if err != nil {
+		return nil, err
+	}
+	return serializeProfiles(merged), nil
+}
+
+// blockKey identifies a block's source span, independent of which input
+// file it was read from, so that equivalent blocks from different
+// profiles can be merged.
+type blockKey struct {
+	StartLine, StartCol int
+	EndLine, EndCol     int
+	NumStmt             int
+}
+
+func keyOf(b ProfileBlock) blockKey {
+	return blockKey{b.StartLine, b.StartCol, b.EndLine, b.EndCol, b.NumStmt}
+}
+
+// mergeProfiles merges the profiles parsed from several input files
+// into one set, as `go tool covdata merge` and `go tool cover` do for
+// their own inputs. "set" mode profiles are merged by ORing their hit
+// bits together; "count"/"atomic" profiles are merged by summing hit
+// counts. Blocks present in only some inputs are kept as-is. Merging
+// profiles with different modes is rejected, since the semantics of
+// the resulting counts would be undefined.
+func mergeProfiles(sets ...[]*Profile) ([]*Profile, error) {
+	byFile := make(map[string]*Profile)
+	var order []string
+	mode := ""
+
+	for _, profiles := range sets {
+		for _, p := range profiles {
+			if mode == "" {
+				mode = p.Mode
+			} else if mode != p.Mode {
+				return nil, fmt.Errorf("cannot merge profiles with mode %q and mode %q", mode, p.Mode)
+			}
+
+			dst, ok := byFile[p.FileName]
+			if !ok {
+				dst = &Profile{FileName: p.FileName, Mode: p.Mode}
+				byFile[p.FileName] = dst
+				order = append(order, p.FileName)
+			}
+			mergeBlocksInto(dst, p.Blocks, mode)
+		}
+	}
+
+	merged := make([]*Profile, 0, len(order))
+	for _, fn := range order {
+		merged = append(merged, byFile[fn])
+	}
+	sort.Sort(profilesByFileName(merged))
+	for _, p := range merged {
+		sort.Sort(blocksByStart(p.Blocks))
+	}
+	return merged, nil
+}
+
+// mergeBlocksInto folds blocks into dst.Blocks, combining counts for
+// blocks that share the same source span according to mode.
+func mergeBlocksInto(dst *Profile, blocks []ProfileBlock, mode string) {
+	idx := make(map[blockKey]int, len(dst.Blocks))
+	for i, b := range dst.Blocks {
+		idx[keyOf(b)] = i
+	}
+	for _, b := range blocks {
+		k := keyOf(b)
+		if i, ok := idx[k]; ok {
+			if mode == "set" {
+				if b.Count > 0 {
+					dst.Blocks[i].Count = 1
+				}
+			} else {
+				dst.Blocks[i].Count += b.Count
+			}
+			continue
+		}
+		idx[k] = len(dst.Blocks)
+		dst.Blocks = append(dst.Blocks, b)
+	}
+}
+
+// serializeProfiles renders profiles back into the text "mode: ..."
+// format, so that a merged set of profiles can be fed through the
+// existing text-based parsing/rendering pipeline.
+func serializeProfiles(profiles []*Profile) []byte {
+	var buf bytes.Buffer
+	mode := "set"
+	if len(profiles) > 0 {
+		mode = profiles[0].Mode
+	}
+	fmt.Fprintf(&buf, "mode: %s\n", mode)
+	for _, p := range profiles {
+		for _, b := range p.Blocks {
+			fmt.Fprintf(&buf, "%s:%d.%d,%d.%d %d %d\n",
+				p.FileName, b.StartLine, b.StartCol, b.EndLine, b.EndCol, b.NumStmt, b.Count)
+		}
+	}
+	return buf.Bytes()
+}
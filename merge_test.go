@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeProfilesSetModeOrsHitBits(t *testing.T) {
+	a := []*Profile{{FileName: "f.go", Mode: "set", Blocks: []ProfileBlock{
+		{StartLine: 1, StartCol: 1, EndLine: 1, EndCol: 2, NumStmt: 1, Count: 0},
+	}}}
+	b := []*Profile{{FileName: "f.go", Mode: "set", Blocks: []ProfileBlock{
+		{StartLine: 1, StartCol: 1, EndLine: 1, EndCol: 2, NumStmt: 1, Count: 1},
+	}}}
+
+	merged, err := mergeProfiles(a, b)
+	require.NoError(t, err)
+	require.Len(t, merged, 1)
+	require.Len(t, merged[0].Blocks, 1)
+	require.Equal(t, 1, merged[0].Blocks[0].Count)
+}
+
+func TestMergeProfilesCountModeSumsHits(t *testing.T) {
+	a := []*Profile{{FileName: "f.go", Mode: "count", Blocks: []ProfileBlock{
+		{StartLine: 1, StartCol: 1, EndLine: 1, EndCol: 2, NumStmt: 1, Count: 3},
+	}}}
+	b := []*Profile{{FileName: "f.go", Mode: "count", Blocks: []ProfileBlock{
+		{StartLine: 1, StartCol: 1, EndLine: 1, EndCol: 2, NumStmt: 1, Count: 4},
+	}}}
+
+	merged, err := mergeProfiles(a, b)
+	require.NoError(t, err)
+	require.Equal(t, 7, merged[0].Blocks[0].Count)
+}
+
+func TestMergeProfilesKeepsBlocksUniqueToOneInput(t *testing.T) {
+	a := []*Profile{{FileName: "f.go", Mode: "count", Blocks: []ProfileBlock{
+		{StartLine: 1, StartCol: 1, EndLine: 1, EndCol: 2, NumStmt: 1, Count: 1},
+	}}}
+	b := []*Profile{{FileName: "f.go", Mode: "count", Blocks: []ProfileBlock{
+		{StartLine: 2, StartCol: 1, EndLine: 2, EndCol: 2, NumStmt: 1, Count: 1},
+	}}}
+
+	merged, err := mergeProfiles(a, b)
+	require.NoError(t, err)
+	require.Len(t, merged[0].Blocks, 2)
+}
+
+func TestMergeProfilesRejectsModeMismatch(t *testing.T) {
+	a := []*Profile{{FileName: "f.go", Mode: "set"}}
+	b := []*Profile{{FileName: "f.go", Mode: "count"}}
+
+	_, err := mergeProfiles(a, b)
+	require.Error(t, err)
+}
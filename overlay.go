@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+)
+
+// Overlay replaces file paths before they are opened, mirroring the
+// JSON format accepted by `go build -overlay`. It lets gocover-cobertura
+// locate sources in monorepo/Bazel/Buck setups, or in Docker CI, where
+// the coverage profile was produced with source paths that no longer
+// resolve on the machine running the converter.
+type Overlay struct {
+	Replace map[string]string `json:"Replace"`
+}
+
+// loadOverlay reads and parses an overlay file in the JSON format
+// accepted by `go build -overlay`. An empty path is not an error; it
+// simply means no overlay was configured.
+func loadOverlay(path string) (*Overlay, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var o Overlay
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// prefixRemap is a single `-source-prefix old=new` remap.
+type prefixRemap struct {
+	old, new string
+}
+
+// parsePrefixRemaps parses a comma-separated list of old=new remaps, as
+// accepted by -source-prefix.
+func parsePrefixRemaps(s string) []prefixRemap {
+	if s == "" {
+		return nil
+	}
+	var out []prefixRemap
+	for _, part := range strings.Split(s, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		out = append(out, prefixRemap{old: kv[0], new: kv[1]})
+	}
+	return out
+}
+
+// resolve applies o's exact-path replacements, then any prefix remaps,
+// to path in that order. o may be nil.
+func (o *Overlay) resolve(path string, prefixes []prefixRemap) string {
+	if o != nil {
+		if repl, ok := o.Replace[path]; ok {
+			path = repl
+		}
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(path, p.old) {
+			return p.new + strings.TrimPrefix(path, p.old)
+		}
+	}
+	return path
+}
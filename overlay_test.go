@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestOverlayResolve(t *testing.T) {
+	var o *Overlay
+	require.Equal(t, "a.go", o.resolve("a.go", nil))
+
+	o = &Overlay{Replace: map[string]string{"a.go": "b.go"}}
+	require.Equal(t, "b.go", o.resolve("a.go", nil))
+
+	remaps := []prefixRemap{{old: "/src/", new: "/actual/"}}
+	require.Equal(t, "/actual/x.go", o.resolve("/src/x.go", remaps))
+}
+
+func TestLoadOverlay(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "overlay.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"Replace":{"a.go":"b.go"}}`), 0o644))
+
+	o, err := loadOverlay(path)
+	require.NoError(t, err)
+	require.Equal(t, "b.go", o.Replace["a.go"])
+
+	o2, err := loadOverlay("")
+	require.NoError(t, err)
+	require.Nil(t, o2)
+}
+
+func TestParseProfileReadsSourceOverlayRemappedFile(t *testing.T) {
+	dir := t.TempDir()
+	originalPath := filepath.Join(dir, "original.go")
+	actualPath := filepath.Join(dir, "actual.go")
+	require.NoError(t, os.WriteFile(actualPath, []byte("package p\n\nfunc F() {}\n"), 0o644))
+
+	pkg := packages.Package{
+		Name:    "p",
+		GoFiles: []string{originalPath},
+		Module:  &packages.Module{Path: "example.com/p", Dir: dir},
+	}
+	profile := Profile{FileName: "example.com/p/original.go"}
+	ignore := &Ignore{SourceOverlay: &Overlay{Replace: map[string]string{originalPath: actualPath}}}
+
+	v := Coverage{}
+	// originalPath is never created on disk; a successful parse here
+	// can only have come from reading the overlay-remapped actualPath.
+	err := v.parseProfile(&profile, &pkg, ignore)
+	require.NoError(t, err)
+	require.Len(t, v.Packages, 1)
+	require.Len(t, v.Packages[0].Classes, 1)
+	require.Equal(t, "original.go", v.Packages[0].Classes[0].Filename)
+}
+
+func TestParsePrefixRemaps(t *testing.T) {
+	remaps := parsePrefixRemaps("/a=/b,/c=/d")
+	require.Len(t, remaps, 2)
+	require.Equal(t, "/a", remaps[0].old)
+	require.Equal(t, "/b", remaps[0].new)
+	require.Equal(t, "/c", remaps[1].old)
+	require.Equal(t, "/d", remaps[1].new)
+
+	require.Nil(t, parsePrefixRemaps(""))
+}
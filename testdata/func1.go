@@ -0,0 +1,8 @@
+package testdata
+
+func Func1() {
+	x := 1
+	if x == 1 {
+		x = 2
+	}
+}
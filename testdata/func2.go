@@ -0,0 +1,15 @@
+package testdata
+
+type Type1 struct{}
+
+func (t Type1) Method1() {
+	_ = 1
+}
+
+func (t Type1) Method2() {
+	_ = 1
+}
+
+func (t Type1) Method3() {
+	_ = 1
+}
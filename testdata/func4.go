@@ -0,0 +1,5 @@
+package testdata
+
+func Func4() {
+	_ = 1
+}
@@ -0,0 +1,5 @@
+package testdata
+
+func Func5() {
+	_ = 1
+}